@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// cmdBundle returns the "wolfictl bundle" command, which groups together
+// operations on wolfictl bundles: multi-arch OCI indexes that package up a
+// melange build graph for execution, whether on Kubernetes (Podspec),
+// locally via podman/docker (LocalRun), or on a plain Linux host via
+// systemd (SystemdUnit/generate-systemd).
+func cmdBundle() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Operate on wolfictl bundles",
+	}
+
+	cmd.AddCommand(
+		cmdBundleBuild(),
+		cmdBundleRun(),
+		cmdBundlePull(),
+		cmdBundleGenerateSystemd(),
+	)
+
+	return cmd
+}
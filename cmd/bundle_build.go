@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+
+	"github.com/wolfi-dev/wolfictl/pkg/internal/bundle"
+)
+
+// cmdBundleBuild returns the "wolfictl bundle build" command, which prints
+// the Pod spec for running a single bundle task/arch on Kubernetes.
+func cmdBundleBuild() *cobra.Command {
+	var (
+		pkg, path, sourceDir, arch, mFamily, sa, ns string
+		rootless                                    bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "build <bundle-ref>",
+		Short: "Print the Pod spec for running a bundle task",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := name.ParseReference(args[0])
+			if err != nil {
+				return fmt.Errorf("parsing bundle ref %q: %w", args[0], err)
+			}
+
+			task := bundle.Task{
+				Package:        pkg,
+				Path:           path,
+				SourceDir:      sourceDir,
+				BuildDateEpoch: time.Now(),
+			}
+
+			pod, err := bundle.Podspec(task, ref, arch, mFamily, sa, ns, rootless)
+			if err != nil {
+				return fmt.Errorf("building podspec: %w", err)
+			}
+
+			out, err := yaml.Marshal(pod)
+			if err != nil {
+				return fmt.Errorf("marshaling podspec: %w", err)
+			}
+
+			_, err = cmd.OutOrStdout().Write(out)
+			return err
+		},
+	}
+
+	cmd.Flags().StringVar(&pkg, "package", "", "package name for the task")
+	cmd.Flags().StringVar(&path, "path", "", "melange config path for the task")
+	cmd.Flags().StringVar(&sourceDir, "source-dir", "", "source directory for the task")
+	cmd.Flags().StringVar(&arch, "arch", "x86_64", "architecture to build the Pod spec for")
+	cmd.Flags().StringVar(&mFamily, "machine-family", "", "GKE node machine family to request")
+	cmd.Flags().StringVar(&sa, "service-account", "", "Kubernetes service account to run as")
+	cmd.Flags().StringVar(&ns, "namespace", "", "Kubernetes namespace to schedule into")
+	cmd.Flags().BoolVar(&rootless, "rootless", false, "drop Privileged in favor of RunAsNonRoot and user-namespace remapping")
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/cobra"
+
+	"github.com/wolfi-dev/wolfictl/pkg/internal/bundle"
+)
+
+// cmdBundleGenerateSystemd returns the "wolfictl bundle generate-systemd"
+// command, which writes one systemd unit (or, with --quadlet, one
+// podman-quadlet .container file) per package/arch in a bundle, for
+// operators running wolfi builds on plain Linux hosts or build farms that
+// don't run Kubernetes.
+func cmdBundleGenerateSystemd() *cobra.Command {
+	var (
+		runtime string
+		quadlet bool
+		outDir  string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "generate-systemd <bundle-ref>",
+		Short: "Generate systemd (or podman-quadlet) units for a bundle's tasks",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			pull := args[0]
+
+			// TODO: verification requires a configured Signer/Verifier (e.g.
+			// cosign keyless or KMS); until wolfictl ships one, bundles are
+			// pulled unverified here.
+			bundles, err := bundle.Pull(cmd.Context(), pull, nil)
+			if err != nil {
+				return fmt.Errorf("pulling bundle %q: %w", pull, err)
+			}
+
+			ref, err := name.ParseReference(pull)
+			if err != nil {
+				return fmt.Errorf("parsing bundle ref %q: %w", pull, err)
+			}
+
+			if err := os.MkdirAll(outDir, 0o755); err != nil {
+				return fmt.Errorf("creating output dir %q: %w", outDir, err)
+			}
+
+			for _, task := range bundles.Tasks {
+				for _, arch := range task.Architectures {
+					unit, err := bundle.SystemdUnit(task, ref, arch, bundle.UnitOptions{
+						Runtime: runtime,
+						Quadlet: quadlet,
+					})
+					if err != nil {
+						return fmt.Errorf("generating unit for %s/%s: %w", task.Package, arch, err)
+					}
+
+					ext := "service"
+					if quadlet {
+						ext = "container"
+					}
+
+					out := filepath.Join(outDir, fmt.Sprintf("%s-%s.%s", task.Package, arch, ext))
+					if err := os.WriteFile(out, unit, 0o644); err != nil {
+						return fmt.Errorf("writing %s: %w", out, err)
+					}
+				}
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&runtime, "runtime", "podman", "container runtime invoked by generated units")
+	cmd.Flags().BoolVar(&quadlet, "quadlet", false, "generate podman-quadlet .container files instead of plain systemd units")
+	cmd.Flags().StringVar(&outDir, "output-dir", ".", "directory to write generated units to")
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/wolfi-dev/wolfictl/pkg/internal/bundle"
+)
+
+// cmdBundlePull returns the "wolfictl bundle pull" command, which fetches a
+// bundle's graph/tasks/runtime layers without verifying its signature. No
+// Verifier is configured, so --verify is rejected with an error.
+func cmdBundlePull() *cobra.Command {
+	var verify bool
+
+	cmd := &cobra.Command{
+		Use:   "pull <bundle-ref>",
+		Short: "Pull a bundle and summarize its tasks",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var verifier bundle.Verifier
+			if verify {
+				// TODO: wire in a real Signer/Verifier (cosign keyless or
+				// KMS) once wolfictl ships one; until then --verify can't
+				// be honored.
+				return fmt.Errorf("--verify requires a configured Verifier, which is not yet implemented")
+			}
+
+			bundles, err := bundle.Pull(cmd.Context(), args[0], verifier)
+			if err != nil {
+				return fmt.Errorf("pulling bundle %q: %w", args[0], err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "%d task(s):\n", len(bundles.Tasks))
+			for _, task := range bundles.Tasks {
+				fmt.Fprintf(cmd.OutOrStdout(), "  %s (%v)\n", task.Package, task.Architectures)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&verify, "verify", false, "verify the bundle's signature before pulling (no Verifier is configured, so setting this returns an error)")
+
+	return cmd
+}
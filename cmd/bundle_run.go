@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/spf13/cobra"
+
+	"github.com/wolfi-dev/wolfictl/pkg/internal/bundle"
+)
+
+// cmdBundleRun returns the "wolfictl bundle run" command, which runs a
+// bundle task locally via podman/docker, as an alternative to running the
+// Pod spec that "wolfictl bundle build" prints on Kubernetes.
+func cmdBundleRun() *cobra.Command {
+	var (
+		pkg, path, sourceDir, arch, outputDir, runtime string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run <bundle-ref>",
+		Short: "Run a bundle task locally via podman/docker",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ref, err := name.ParseReference(args[0])
+			if err != nil {
+				return fmt.Errorf("parsing bundle ref %q: %w", args[0], err)
+			}
+
+			task := bundle.Task{
+				Package:        pkg,
+				Path:           path,
+				SourceDir:      sourceDir,
+				BuildDateEpoch: time.Now(),
+			}
+
+			return bundle.LocalRun(cmd.Context(), task, ref, arch, bundle.LocalRunOptions{
+				Runtime:   runtime,
+				OutputDir: outputDir,
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&pkg, "package", "", "package name for the task")
+	cmd.Flags().StringVar(&path, "path", "", "melange config path for the task")
+	cmd.Flags().StringVar(&sourceDir, "source-dir", "", "source directory for the task")
+	cmd.Flags().StringVar(&arch, "arch", "x86_64", "architecture to run the task for")
+	cmd.Flags().StringVar(&outputDir, "output-dir", ".", "host directory to receive built packages")
+	cmd.Flags().StringVar(&runtime, "runtime", "podman", "container runtime to shell out to")
+
+	return cmd
+}
@@ -71,42 +71,97 @@ func (req Request) Validate() error {
 	return errors.Join(errs...)
 }
 
+// maxAliasResolutionIterations bounds the transitive-closure loop in
+// ResolveAliases so that a cycle between alias finders (e.g. two sources that
+// disagree and keep "discovering" each other's IDs) can't loop forever.
+const maxAliasResolutionIterations = 5
+
 // ResolveAliases ensures that any CVE IDs and GHSA IDs for the request's
 // vulnerability are discovered and stored as Aliases, based on the initial set
-// of known aliases.
-func (req Request) ResolveAliases(ctx context.Context, af AliasFinder) (*Request, error) {
+// of known aliases. It queries every finder in afs (e.g. GitHub Security
+// Advisories, OSV.dev, NVD, distro trackers) and repeats until a full pass
+// over all finders turns up no new IDs, or until maxAliasResolutionIterations
+// passes have run, so that aliases discovered via one source can in turn be
+// resolved through another.
+//
+// A single finder erroring doesn't abort resolution: errors are collected and
+// returned joined alongside whatever aliases were otherwise resolved, so that
+// one upstream outage doesn't block advisory creation.
+func (req Request) ResolveAliases(ctx context.Context, afs ...AliasFinder) (*Request, error) {
 	logger := clog.FromContext(ctx)
 
-	var newAliases []string
-
+	seen := make(map[string]bool)
 	for _, alias := range req.Aliases {
-		switch {
-		case vuln.RegexGHSA.MatchString(alias):
-			cve, err := af.CVEForGHSA(ctx, alias)
-			if err != nil {
-				return nil, fmt.Errorf("resolving GHSA %q: %w", alias, err)
-			}
+		seen[alias] = true
+	}
 
-			newAliases = append(newAliases, cve)
-			continue
+	queue := slices.Clone(req.Aliases)
 
-		case vuln.RegexCVE.MatchString(alias):
-			ghsas, err := af.GHSAsForCVE(ctx, alias)
-			if err != nil {
-				return nil, fmt.Errorf("resolving CVE %q: %w", alias, err)
-			}
+	type cacheKey struct {
+		finder int
+		alias  string
+	}
+	cache := make(map[cacheKey][]string)
 
-			newAliases = append(newAliases, ghsas...)
-			continue
+	var errs []error
 
-		default:
-			logger.Warnf("not resolving aliases for unknown vulnerability ID format: %q", alias)
+	for i := 0; i < maxAliasResolutionIterations && len(queue) > 0; i++ {
+		next := queue
+		queue = nil
+
+		for _, alias := range next {
+			var resolved []string
+
+			switch {
+			case vuln.RegexGHSA.MatchString(alias):
+				for fi, af := range afs {
+					key := cacheKey{fi, alias}
+					ids, ok := cache[key]
+					if !ok {
+						cve, err := af.CVEForGHSA(ctx, alias)
+						if err != nil {
+							errs = append(errs, fmt.Errorf("resolving GHSA %q: %w", alias, err))
+							continue
+						}
+						ids = []string{cve}
+						cache[key] = ids
+					}
+					resolved = append(resolved, ids...)
+				}
+
+			case vuln.RegexCVE.MatchString(alias):
+				for fi, af := range afs {
+					key := cacheKey{fi, alias}
+					ids, ok := cache[key]
+					if !ok {
+						ghsas, err := af.GHSAsForCVE(ctx, alias)
+						if err != nil {
+							errs = append(errs, fmt.Errorf("resolving CVE %q: %w", alias, err))
+							continue
+						}
+						ids = ghsas
+						cache[key] = ids
+					}
+					resolved = append(resolved, ids...)
+				}
+
+			default:
+				logger.Warnf("not resolving aliases for unknown vulnerability ID format: %q", alias)
+			}
+
+			for _, id := range resolved {
+				if id == "" || seen[id] {
+					continue
+				}
+				seen[id] = true
+				req.Aliases = append(req.Aliases, id)
+				queue = append(queue, id)
+			}
 		}
 	}
 
-	req.Aliases = append(req.Aliases, newAliases...)
 	slices.Sort(req.Aliases)
 	req.Aliases = slices.Compact(req.Aliases)
 
-	return &req, nil
+	return &req, errors.Join(errs...)
 }
@@ -0,0 +1,117 @@
+package advisory
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+// fakeAliasFinder is a test double for AliasFinder. err, if set, is
+// returned from every call. calls counts invocations per input ID.
+type fakeAliasFinder struct {
+	cveForGHSA  map[string]string
+	ghsasForCVE map[string][]string
+	err         error
+	calls       map[string]int
+}
+
+func newFakeAliasFinder() *fakeAliasFinder {
+	return &fakeAliasFinder{
+		cveForGHSA:  map[string]string{},
+		ghsasForCVE: map[string][]string{},
+		calls:       map[string]int{},
+	}
+}
+
+func (f *fakeAliasFinder) CVEForGHSA(_ context.Context, ghsa string) (string, error) {
+	f.calls[ghsa]++
+	if f.err != nil {
+		return "", f.err
+	}
+	return f.cveForGHSA[ghsa], nil
+}
+
+func (f *fakeAliasFinder) GHSAsForCVE(_ context.Context, cve string) ([]string, error) {
+	f.calls[cve]++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.ghsasForCVE[cve], nil
+}
+
+func contains(ids []string, id string) bool {
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+func TestResolveAliases_TransitiveClosure(t *testing.T) {
+	// af1 knows CVE-2021-12345 aliases to GHSA-2222-3333-4444.
+	// af2 only knows GHSA-2222-3333-4444 aliases to CVE-2021-22222, a
+	// second CVE that's unreachable in a single hop from the original ID.
+	af1 := newFakeAliasFinder()
+	af1.ghsasForCVE["CVE-2021-12345"] = []string{"GHSA-2222-3333-4444"}
+
+	af2 := newFakeAliasFinder()
+	af2.cveForGHSA["GHSA-2222-3333-4444"] = "CVE-2021-22222"
+
+	req := Request{Aliases: []string{"CVE-2021-12345"}}
+
+	got, err := req.ResolveAliases(context.Background(), af1, af2)
+	if err != nil {
+		t.Fatalf("ResolveAliases: %v", err)
+	}
+
+	for _, want := range []string{"CVE-2021-12345", "GHSA-2222-3333-4444", "CVE-2021-22222"} {
+		if !contains(got.Aliases, want) {
+			t.Errorf("Aliases = %v, want to contain %q", got.Aliases, want)
+		}
+	}
+}
+
+func TestResolveAliases_BoundsCyclicalResolution(t *testing.T) {
+	// A finder where every CVE aliases to a GHSA that aliases back to the
+	// very same CVE: ResolveAliases must terminate and record each ID once.
+	af := newFakeAliasFinder()
+	af.ghsasForCVE["CVE-2021-12345"] = []string{"GHSA-2222-3333-4444"}
+	af.cveForGHSA["GHSA-2222-3333-4444"] = "CVE-2021-12345"
+
+	req := Request{Aliases: []string{"CVE-2021-12345"}}
+
+	got, err := req.ResolveAliases(context.Background(), af)
+	if err != nil {
+		t.Fatalf("ResolveAliases: %v", err)
+	}
+
+	want := []string{"CVE-2021-12345", "GHSA-2222-3333-4444"}
+	if len(got.Aliases) != len(want) {
+		t.Fatalf("Aliases = %v, want exactly %v", got.Aliases, want)
+	}
+	for _, w := range want {
+		if !contains(got.Aliases, w) {
+			t.Errorf("Aliases = %v, want to contain %q", got.Aliases, w)
+		}
+	}
+}
+
+func TestResolveAliases_JoinsErrorsWithoutAborting(t *testing.T) {
+	failing := newFakeAliasFinder()
+	failing.err = errors.New("upstream outage")
+
+	working := newFakeAliasFinder()
+	working.ghsasForCVE["CVE-2021-12345"] = []string{"GHSA-2222-3333-4444"}
+
+	req := Request{Aliases: []string{"CVE-2021-12345"}}
+
+	got, err := req.ResolveAliases(context.Background(), failing, working)
+	if err == nil {
+		t.Fatal("ResolveAliases: want error from the failing finder, got nil")
+	}
+
+	if !contains(got.Aliases, "GHSA-2222-3333-4444") {
+		t.Errorf("Aliases = %v, want to contain the ID found by the working finder despite the other finder's error", got.Aliases)
+	}
+}
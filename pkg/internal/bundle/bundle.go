@@ -3,6 +3,7 @@ package bundle
 import (
 	"archive/tar"
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -58,15 +59,34 @@ func ParseGCSFuseMount(s string) (*GCSFuseMount, error) {
 type Entrypoint struct {
 	Flags         []string
 	GCSFuseMounts []*GCSFuseMount
+
+	// Rootless indicates the entrypoint runs in a container without
+	// Privileged access (see Podspec's rootless mode). gcsfuse requires FUSE
+	// mounts, which require privileges, so GCSFuseMounts are skipped and
+	// must instead be provided via a sidecar CSI mount.
+	Rootless bool
+
+	// Local indicates the entrypoint is running outside of the
+	// PACKAGES_UPLOAD_URL upload flow (see LocalRun), so built packages are
+	// copied to LocalOutputMount instead of curled to a remote bucket.
+	Local bool
 }
 
+// LocalOutputMount is the in-container path that receives packages.tar.gz
+// when Entrypoint.Local is set. LocalRun bind-mounts its OutputDir here.
+const LocalOutputMount = "/work/packages"
+
 const entrypointTemplate = `# generated by wolfictl bundle
 set -eux
 
+{{ if .Rootless }}
+{{ if .GCSFuseMounts }}# gcsfuse mounts are skipped in rootless mode; provide them via a sidecar CSI mount instead.{{ end }}
+{{ else }}
 {{ range .GCSFuseMounts }}
 mkdir -p {{ .Mount }}
 gcsfuse -o ro --implicit-dirs {{ if .OnlyDir }} --only-dir {{ .OnlyDir }} {{ end }} {{ .Bucket }} {{ .Mount }}
 {{ end }}
+{{ end }}
 
 # TODO: Should this be in the bundle?
 melange keygen local-melange.rsa
@@ -83,8 +103,13 @@ melange build $1 \
 
 tar -C packages -czvf packages.tar.gz .
 
+{{ if .Local }}
+mkdir -p ` + LocalOutputMount + `
+cp packages.tar.gz ` + LocalOutputMount + `/packages.tar.gz
+{{ else }}
 # TODO: Content-Type
 curl --upload-file packages.tar.gz -H "Content-Type: application/octet-stream" $PACKAGES_UPLOAD_URL
+{{ end }}
 
 sha256sum packages.tar.gz
 sha256sum packages.tar.gz | cut -d' ' -f1 > /dev/termination-log
@@ -248,12 +273,18 @@ type Bundles struct {
 }
 
 // TODO: dependency injection
-func Pull(pull string) (*Bundles, error) {
+func Pull(ctx context.Context, pull string, verifier Verifier) (*Bundles, error) {
 	ref, err := name.ParseReference(pull)
 	if err != nil {
 		return nil, err
 	}
 
+	if verifier != nil {
+		if err := Verify(ctx, ref, verifier); err != nil {
+			return nil, fmt.Errorf("verifying bundle %s: %w", pull, err)
+		}
+	}
+
 	idx, err := remote.Index(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithUserAgent("wolfictl bundle"))
 	if err != nil {
 		return nil, err
@@ -336,9 +367,35 @@ func escapeRFC1123(s string) string {
 	return strings.ToLower(strings.ReplaceAll(strings.ReplaceAll(s, ".", "-"), "_", "-"))
 }
 
+// nonRootUID and nonRootGID are the uid/gid the workspace container runs as
+// in rootless mode, matching the "nonroot" user baked into wolfi-base images.
+const (
+	nonRootUID = 65532
+	nonRootGID = 65532
+)
+
+// containerSecurityContext returns the SecurityContext for the workspace
+// container. Privileged mode is required for gcsfuse, which FUSE mounts
+// require privileges for; rootless mode drops Privileged in favor of
+// RunAsNonRoot plus user-namespace remapping (see Podspec's HostUsers).
+func containerSecurityContext(rootless bool) *corev1.SecurityContext {
+	if !rootless {
+		return &corev1.SecurityContext{
+			Privileged: ptr.Bool(true),
+		}
+	}
+
+	return &corev1.SecurityContext{
+		Privileged:   ptr.Bool(false),
+		RunAsNonRoot: ptr.Bool(true),
+		RunAsUser:    ptr.Int64(nonRootUID),
+		RunAsGroup:   ptr.Int64(nonRootGID),
+	}
+}
+
 // Podspec returns bytes of yaml representing a podspec.
 // This is a terrible API that we should change.
-func Podspec(task Task, ref name.Reference, arch, mFamily, sa, ns string) (*corev1.Pod, error) {
+func Podspec(task Task, ref name.Reference, arch, mFamily, sa, ns string, rootless bool) (*corev1.Pod, error) {
 	goarch := types.ParseArchitecture(arch).String()
 
 	// Set some sane default resource requests if none are specified by flag or config.
@@ -443,9 +500,7 @@ func Podspec(task Task, ref name.Reference, arch, mFamily, sa, ns string) (*core
 						MountPath: "/tmp",
 					},
 				},
-				SecurityContext: &corev1.SecurityContext{
-					Privileged: ptr.Bool(true),
-				},
+				SecurityContext: containerSecurityContext(rootless),
 			}},
 			RestartPolicy:                corev1.RestartPolicyNever,
 			AutomountServiceAccountToken: ptr.Bool(false),
@@ -454,6 +509,7 @@ func Podspec(task Task, ref name.Reference, arch, mFamily, sa, ns string) (*core
 			},
 			Tolerations:        t,
 			ServiceAccountName: sa,
+			HostUsers:          ptr.Bool(!rootless),
 			SecurityContext: &corev1.PodSecurityContext{
 				SeccompProfile: &corev1.SeccompProfile{
 					Type: corev1.SeccompProfileTypeRuntimeDefault,
@@ -0,0 +1,100 @@
+package bundle
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+
+	"chainguard.dev/apko/pkg/build/types"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+// LocalRunOptions configures LocalRun.
+type LocalRunOptions struct {
+	// Runtime is the container runtime binary to shell out to, e.g. "podman"
+	// or "docker". Defaults to "podman" if empty.
+	Runtime string
+
+	// OutputDir is a host directory that receives the built packages. It is
+	// bind-mounted in place of the PACKAGES_UPLOAD_URL upload that the
+	// Kubernetes entrypoint performs.
+	OutputDir string
+
+	// Stdout and Stderr, if set, receive the container's output. Defaults to
+	// os.Stdout/os.Stderr.
+	Stdout, Stderr *os.File
+}
+
+// LocalRun runs the bundle image for task/arch locally via podman (or
+// docker), as an alternative to scheduling a Podspec Pod on Kubernetes. It
+// bind-mounts task.SourceDir from the host and an output directory in place
+// of the PACKAGES_UPLOAD_URL upload the Kubernetes entrypoint performs.
+//
+// ref must have been built (via New) with an Entrypoint whose Local field is
+// set, so that its baked-in entrypoint.sh writes packages.tar.gz to
+// LocalOutputMount instead of curling it to PACKAGES_UPLOAD_URL, which
+// LocalRun never sets.
+func LocalRun(ctx context.Context, task Task, ref name.Reference, arch string, opts LocalRunOptions) error {
+	runtime := opts.Runtime
+	if runtime == "" {
+		runtime = "podman"
+	}
+
+	if opts.OutputDir == "" {
+		return fmt.Errorf("LocalRunOptions.OutputDir must be set")
+	}
+	if err := os.MkdirAll(opts.OutputDir, 0o755); err != nil {
+		return fmt.Errorf("creating output dir %q: %w", opts.OutputDir, err)
+	}
+	if err := os.MkdirAll(task.SourceDir, 0o755); err != nil {
+		return fmt.Errorf("creating source dir %q: %w", task.SourceDir, err)
+	}
+
+	args := localRunArgs(task, ref, arch, opts)
+
+	cmd := exec.CommandContext(ctx, runtime, args...)
+	cmd.Stdout = opts.Stdout
+	if cmd.Stdout == nil {
+		cmd.Stdout = os.Stdout
+	}
+	cmd.Stderr = opts.Stderr
+	if cmd.Stderr == nil {
+		cmd.Stderr = os.Stderr
+	}
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("%s run %s: %w", runtime, strconv.Quote(ref.String()), err)
+	}
+
+	return nil
+}
+
+// localRunArgs builds the podman/docker CLI args for LocalRun. It's
+// factored out of LocalRun so the argument construction (platform string,
+// resource flags, bind mounts) can be unit tested without shelling out.
+func localRunArgs(task Task, ref name.Reference, arch string, opts LocalRunOptions) []string {
+	goarch := types.ParseArchitecture(arch).String()
+
+	args := []string{
+		"run", "--rm",
+		"--platform", "linux/" + goarch,
+		"-e", fmt.Sprintf("SOURCE_DATE_EPOCH=%d", task.BuildDateEpoch.Unix()),
+		"-v", fmt.Sprintf("%s:%s", task.SourceDir, task.SourceDir),
+		// Replaces the curl upload of packages.tar.gz to PACKAGES_UPLOAD_URL
+		// in the generated entrypoint with a local bind-mount.
+		"-v", fmt.Sprintf("%s:%s", opts.OutputDir, LocalOutputMount),
+	}
+
+	if in := task.Resources; in != nil {
+		if in.CPU != "" {
+			args = append(args, "--cpus", in.CPU)
+		}
+		if in.Memory != "" {
+			args = append(args, "--memory", in.Memory)
+		}
+	}
+
+	return append(args, ref.String(), task.Path, task.SourceDir)
+}
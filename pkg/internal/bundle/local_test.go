@@ -0,0 +1,82 @@
+package bundle
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"chainguard.dev/melange/pkg/config"
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+func TestLocalRunArgsConvertsArchToGOARCH(t *testing.T) {
+	ref, err := name.ParseReference("example.com/bundle:latest")
+	if err != nil {
+		t.Fatalf("ParseReference: %v", err)
+	}
+
+	task := Task{
+		Path:      "/melange.yaml",
+		SourceDir: "/src",
+	}
+
+	args := localRunArgs(task, ref, "x86_64", LocalRunOptions{OutputDir: "/out"})
+
+	wantPlatform := "linux/amd64"
+	if !slices.Contains(args, wantPlatform) {
+		t.Errorf("args = %v, want to contain platform %q (converted from wolfi-style arch)", args, wantPlatform)
+	}
+	if slices.Contains(args, "linux/x86_64") {
+		t.Errorf("args = %v, want arch converted to GOARCH, not passed through raw", args)
+	}
+}
+
+func TestLocalRunArgsResourcesAndMounts(t *testing.T) {
+	ref, err := name.ParseReference("example.com/bundle:latest")
+	if err != nil {
+		t.Fatalf("ParseReference: %v", err)
+	}
+
+	task := Task{
+		Path:      "/melange.yaml",
+		SourceDir: "/src",
+		Resources: &config.Resources{
+			CPU:    "2",
+			Memory: "4Gi",
+		},
+		BuildDateEpoch: time.Unix(1234, 0),
+	}
+
+	args := localRunArgs(task, ref, "aarch64", LocalRunOptions{OutputDir: "/out"})
+
+	for _, want := range [][]string{
+		{"--platform", "linux/arm64"},
+		{"-e", "SOURCE_DATE_EPOCH=1234"},
+		{"-v", "/src:/src"},
+		{"-v", "/out:" + LocalOutputMount},
+		{"--cpus", "2"},
+		{"--memory", "4Gi"},
+	} {
+		if !containsSubslice(args, want) {
+			t.Errorf("args = %v, want to contain %v", args, want)
+		}
+	}
+
+	last := args[len(args)-3:]
+	wantLast := []string{ref.String(), task.Path, task.SourceDir}
+	if !slices.Equal(last, wantLast) {
+		t.Errorf("trailing args = %v, want %v", last, wantLast)
+	}
+}
+
+func containsSubslice(haystack, needle []string) bool {
+	if len(needle) == 0 || len(needle) > len(haystack) {
+		return false
+	}
+	for i := 0; i+len(needle) <= len(haystack); i++ {
+		if slices.Equal(haystack[i:i+len(needle)], needle) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,255 @@
+package bundle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/partial"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+// signatureAnnotation marks a manifest in the bundle index as a detached
+// signature over one of the index's own per-arch manifests, rather than a
+// graph/tasks/runtime/workspace entry.
+const signatureAnnotation = "dev.wolfi.bundle.signature-for"
+
+// Signer signs an arbitrary digest, either via a keyless Fulcio flow or a
+// KMS/key-file backed key, and returns the resulting signature payload and
+// certificate bundle to attach to the signed image.
+type Signer interface {
+	SignDigest(ctx context.Context, digest v1.Hash) (signature, bundle []byte, err error)
+}
+
+// Verifier checks a signature against an identity/issuer policy (e.g. a
+// keyless Fulcio certificate's SAN and OIDC issuer, or a static public key).
+type Verifier interface {
+	VerifyDigest(ctx context.Context, digest v1.Hash, signature, bundle []byte) error
+}
+
+// Sign attaches a cosign-style signature to each per-arch manifest of idx,
+// and separately signs the digest of the resulting index itself.
+//
+// The index-level signature can't be embedded as a manifest inside the
+// index it covers - appending anything to idx would change idx's own
+// digest, invalidating the very signature being attached. So instead, once
+// idx (including the per-arch manifest signatures) is fully built and its
+// digest is therefore final, Sign pushes the index-level signature directly
+// to registry as a separate artifact, tagged from that digest via sigTag.
+// Verify locates it the same way.
+//
+// Sign returns the index for the caller to push to ref; the caller must push
+// idx unmodified afterward so that its digest matches what was just signed.
+func Sign(ctx context.Context, idx v1.ImageIndex, ref name.Reference, signer Signer) (v1.ImageIndex, error) {
+	im, err := idx.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+
+	signed := idx
+	for _, desc := range im.Manifests { //nolint:gocritic
+		signed, err = signAndAttach(ctx, signed, desc.Digest, signer)
+		if err != nil {
+			return nil, fmt.Errorf("signing manifest %s: %w", desc.Digest, err)
+		}
+	}
+
+	indexDigest, err := signed.Digest()
+	if err != nil {
+		return nil, err
+	}
+
+	sig, bdl, err := signer.SignDigest(ctx, indexDigest)
+	if err != nil {
+		return nil, fmt.Errorf("signing index %s: %w", indexDigest, err)
+	}
+
+	sigImg, err := signatureImage(sig, bdl)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := remote.Write(sigTag(ref, indexDigest), sigImg, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx), remote.WithUserAgent("wolfictl bundle")); err != nil {
+		return nil, fmt.Errorf("pushing index signature for %s: %w", indexDigest, err)
+	}
+
+	return signed, nil
+}
+
+func signAndAttach(ctx context.Context, idx v1.ImageIndex, digest v1.Hash, signer Signer) (v1.ImageIndex, error) {
+	sig, bdl, err := signer.SignDigest(ctx, digest)
+	if err != nil {
+		return nil, err
+	}
+
+	img, err := signatureImage(sig, bdl)
+	if err != nil {
+		return nil, err
+	}
+
+	desc, err := partial.Descriptor(img)
+	if err != nil {
+		return nil, err
+	}
+	desc.Annotations = map[string]string{signatureAnnotation: digest.String()}
+
+	return mutate.AppendManifests(idx, mutate.IndexAddendum{
+		Add:        img,
+		Descriptor: *desc,
+	}), nil
+}
+
+// signatureImage packages a signature/bundle pair as a two-layer image,
+// mirroring how cosign stores signatures alongside the artifact they cover.
+func signatureImage(signature, bundle []byte) (v1.Image, error) {
+	sigLayer, err := staticLayer(signature)
+	if err != nil {
+		return nil, err
+	}
+
+	bundleLayer, err := staticLayer(bundle)
+	if err != nil {
+		return nil, err
+	}
+
+	return mutate.AppendLayers(empty.Image, sigLayer, bundleLayer)
+}
+
+// sigTag derives the tag under which the detached signature for digest is
+// (or will be) published in ref's repository, following cosign's
+// "<repo>:<alg>-<hex>.sig" convention so the companion artifact can be
+// located without any out-of-band bookkeeping.
+func sigTag(ref name.Reference, digest v1.Hash) name.Tag {
+	return ref.Context().Tag(fmt.Sprintf("%s-%s.sig", digest.Algorithm, digest.Hex))
+}
+
+// staticLayer wraps raw bytes as a single-file, uncompressed v1.Layer, for
+// attaching opaque blobs (signatures, certificate bundles) to an image.
+func staticLayer(b []byte) (v1.Layer, error) {
+	return tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(b)), nil
+	})
+}
+
+// Verify fetches ref's bundle index and checks that it, and each of its
+// per-arch manifests, carries a signature attached by Sign that satisfies
+// verifier's identity/issuer policy. Pull, when given a non-nil Verifier,
+// calls Verify before decoding the graph/tasks/runtime layers, so that a
+// compromised registry cannot swap in a malicious task graph or entrypoint
+// script.
+func Verify(ctx context.Context, ref name.Reference, verifier Verifier) error {
+	idx, err := remote.Index(ref, remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithUserAgent("wolfictl bundle"))
+	if err != nil {
+		return err
+	}
+
+	im, err := idx.IndexManifest()
+	if err != nil {
+		return err
+	}
+
+	sigs := map[string]v1.Descriptor{}
+	for _, desc := range im.Manifests { //nolint:gocritic
+		if subject, ok := desc.Annotations[signatureAnnotation]; ok {
+			sigs[subject] = desc
+		}
+	}
+
+	for _, desc := range im.Manifests { //nolint:gocritic
+		if _, ok := desc.Annotations[signatureAnnotation]; ok {
+			continue
+		}
+
+		sigDesc, ok := sigs[desc.Digest.String()]
+		if !ok {
+			return fmt.Errorf("no signature found for manifest %s in bundle %s", desc.Digest, ref)
+		}
+
+		sig, bdl, err := readSignature(idx, sigDesc)
+		if err != nil {
+			return fmt.Errorf("reading signature for %s: %w", desc.Digest, err)
+		}
+
+		if err := verifier.VerifyDigest(ctx, desc.Digest, sig, bdl); err != nil {
+			return fmt.Errorf("verifying signature for %s: %w", desc.Digest, err)
+		}
+	}
+
+	indexDigest, err := idx.Digest()
+	if err != nil {
+		return err
+	}
+
+	sigImg, err := remote.Image(sigTag(ref, indexDigest), remote.WithAuthFromKeychain(authn.DefaultKeychain), remote.WithContext(ctx), remote.WithUserAgent("wolfictl bundle"))
+	if err != nil {
+		return fmt.Errorf("no index signature found for %s in bundle %s: %w", indexDigest, ref, err)
+	}
+
+	layers, err := sigImg.Layers()
+	if err != nil {
+		return err
+	}
+	if len(layers) != 2 {
+		return fmt.Errorf("expected 2 layers (signature, bundle) for index signature, got %d", len(layers))
+	}
+
+	sig, err := readLayer(layers[0])
+	if err != nil {
+		return err
+	}
+
+	bdl, err := readLayer(layers[1])
+	if err != nil {
+		return err
+	}
+
+	if err := verifier.VerifyDigest(ctx, indexDigest, sig, bdl); err != nil {
+		return fmt.Errorf("verifying index signature for %s: %w", indexDigest, err)
+	}
+
+	return nil
+}
+
+func readSignature(idx v1.ImageIndex, desc v1.Descriptor) (signature, bundle []byte, err error) {
+	img, err := idx.Image(desc.Digest)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(layers) != 2 {
+		return nil, nil, fmt.Errorf("expected 2 layers (signature, bundle), got %d", len(layers))
+	}
+
+	signature, err = readLayer(layers[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	bundle, err = readLayer(layers[1])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return signature, bundle, nil
+}
+
+func readLayer(l v1.Layer) ([]byte, error) {
+	rc, err := l.Uncompressed()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return io.ReadAll(rc)
+}
@@ -0,0 +1,175 @@
+package bundle
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// UnitOptions configures SystemdUnit.
+type UnitOptions struct {
+	// Runtime is the container runtime binary the unit shells out to, e.g.
+	// "podman" or "docker". Defaults to "podman" if empty.
+	Runtime string
+
+	// Quadlet, if true, renders a podman-quadlet ".container" unit instead
+	// of a plain systemd service calling out to Runtime.
+	Quadlet bool
+}
+
+type unitData struct {
+	Name      string
+	Runtime   string
+	Image     string
+	Path      string
+	SourceDir string
+	Epoch     int64
+
+	// CPUQuota is a systemd-style percentage, e.g. "200%" for 2 cores.
+	CPUQuota string
+	// PodmanCPUs is a plain core count, e.g. "2", for podman's --cpus.
+	PodmanCPUs string
+
+	// MemoryMax is a systemd-style IEC suffix, e.g. "4G".
+	MemoryMax string
+	// PodmanMemory is a podman/docker-style suffix, e.g. "4g".
+	PodmanMemory string
+}
+
+// systemdUnitTemplate renders a oneshot systemd service equivalent to the Pod
+// returned by Podspec, for operators running wolfi builds on plain Linux
+// hosts or build farms that don't run Kubernetes.
+const systemdUnitTemplate = `[Unit]
+Description=wolfictl bundle task: {{ .Name }}
+
+[Service]
+Type=oneshot
+Environment=SOURCE_DATE_EPOCH={{ .Epoch }}
+{{ if .CPUQuota }}CPUQuota={{ .CPUQuota }}
+{{ end -}}
+{{ if .MemoryMax }}MemoryMax={{ .MemoryMax }}
+{{ end -}}
+ExecStart={{ .Runtime }} run --rm \
+  --tmpfs /tmp \
+  -e SOURCE_DATE_EPOCH \
+  -v {{ .SourceDir }}:{{ .SourceDir }} \
+{{ if .PodmanCPUs }}  --cpus {{ .PodmanCPUs }} \
+{{ end -}}
+{{ if .PodmanMemory }}  --memory {{ .PodmanMemory }} \
+{{ end -}}
+  {{ .Image }} {{ .Path }} {{ .SourceDir }}
+`
+
+// quadletUnitTemplate renders a podman-quadlet ".container" file, which
+// podman-system-generator expands into the systemd unit above.
+const quadletUnitTemplate = `[Unit]
+Description=wolfictl bundle task: {{ .Name }}
+
+[Container]
+Image={{ .Image }}
+Exec={{ .Path }} {{ .SourceDir }}
+Environment=SOURCE_DATE_EPOCH={{ .Epoch }}
+Volume={{ .SourceDir }}:{{ .SourceDir }}
+Tmpfs=/tmp
+{{ if .PodmanCPUs }}PodmanArgs=--cpus={{ .PodmanCPUs }}
+{{ end -}}
+{{ if .PodmanMemory }}PodmanArgs=--memory={{ .PodmanMemory }}
+{{ end -}}
+
+[Service]
+Restart=no
+
+[Install]
+WantedBy=default.target
+`
+
+var (
+	systemdUnitTmpl = template.Must(template.New("systemdUnit").Parse(systemdUnitTemplate))
+	quadletUnitTmpl = template.Must(template.New("quadletUnit").Parse(quadletUnitTemplate))
+)
+
+// SystemdUnit renders a systemd service (or, with opts.Quadlet, a
+// podman-quadlet .container file) that runs task on arch equivalent to the
+// Pod returned by Podspec, following the same pattern podman uses for
+// `generate systemd`/quadlet.
+func SystemdUnit(task Task, ref name.Reference, arch string, opts UnitOptions) ([]byte, error) {
+	runtime := opts.Runtime
+	if runtime == "" {
+		runtime = "podman"
+	}
+
+	data := unitData{
+		Name:      fmt.Sprintf("%s-%s", task.Package, arch),
+		Runtime:   runtime,
+		Image:     ref.String(),
+		Path:      task.Path,
+		SourceDir: task.SourceDir,
+		Epoch:     task.BuildDateEpoch.Unix(),
+	}
+
+	if in := task.Resources; in != nil {
+		if in.CPU != "" {
+			cpu, err := strconv.ParseFloat(in.CPU, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parsing cpu %q: %w", in.CPU, err)
+			}
+			data.CPUQuota = fmt.Sprintf("%d%%", int(cpu*100))
+			data.PodmanCPUs = strconv.FormatFloat(cpu, 'f', -1, 64)
+		}
+
+		if in.Memory != "" {
+			mem, err := iecMemory(in.Memory)
+			if err != nil {
+				return nil, fmt.Errorf("parsing memory %q: %w", in.Memory, err)
+			}
+			data.MemoryMax = mem
+			data.PodmanMemory = strings.ToLower(mem)
+		}
+	}
+
+	tmpl := systemdUnitTmpl
+	if opts.Quadlet {
+		tmpl = quadletUnitTmpl
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// iecMemory converts a Kubernetes resource.Quantity like "4Gi" into the
+// single-letter IEC suffix form ("4G") that both systemd's MemoryMax= and
+// podman/docker's --memory expect, picking the largest unit that divides the
+// value evenly.
+func iecMemory(qty string) (string, error) {
+	q, err := resource.ParseQuantity(qty)
+	if err != nil {
+		return "", err
+	}
+
+	n := q.Value()
+
+	for _, unit := range []struct {
+		suffix string
+		size   int64
+	}{
+		{"T", 1 << 40},
+		{"G", 1 << 30},
+		{"M", 1 << 20},
+		{"K", 1 << 10},
+	} {
+		if n >= unit.size && n%unit.size == 0 {
+			return fmt.Sprintf("%d%s", n/unit.size, unit.suffix), nil
+		}
+	}
+
+	return strconv.FormatInt(n, 10), nil
+}
@@ -0,0 +1,105 @@
+// Package osv implements an advisory.AliasFinder backed by the public
+// OSV.dev API.
+package osv
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// DefaultBaseURL is the public OSV.dev API used when Finder.BaseURL is unset.
+const DefaultBaseURL = "https://api.osv.dev/v1"
+
+// Finder resolves vulnerability ID aliases using the OSV.dev API. It
+// implements the same alias-finding interface as advisory.AliasFinder:
+// CVEForGHSA and GHSAsForCVE, both derived from a single lookup of a
+// vulnerability's "aliases" field.
+type Finder struct {
+	// BaseURL overrides DefaultBaseURL, for testing.
+	BaseURL string
+
+	// Client is the HTTP client used to query OSV.dev. Defaults to
+	// http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// vulnerability is the subset of the OSV schema (https://ossf.github.io/osv-schema/)
+// this package cares about.
+type vulnerability struct {
+	ID      string   `json:"id"`
+	Aliases []string `json:"aliases"`
+}
+
+// CVEForGHSA returns the CVE ID aliased to the given GHSA ID, if any.
+func (f Finder) CVEForGHSA(ctx context.Context, ghsa string) (string, error) {
+	v, err := f.lookup(ctx, ghsa)
+	if err != nil {
+		return "", err
+	}
+
+	for _, alias := range v.Aliases {
+		if strings.HasPrefix(alias, "CVE-") {
+			return alias, nil
+		}
+	}
+
+	return "", nil
+}
+
+// GHSAsForCVE returns the GHSA IDs aliased to the given CVE ID, if any.
+func (f Finder) GHSAsForCVE(ctx context.Context, cve string) ([]string, error) {
+	v, err := f.lookup(ctx, cve)
+	if err != nil {
+		return nil, err
+	}
+
+	var ghsas []string
+	for _, alias := range v.Aliases {
+		if strings.HasPrefix(alias, "GHSA-") {
+			ghsas = append(ghsas, alias)
+		}
+	}
+
+	return ghsas, nil
+}
+
+func (f Finder) lookup(ctx context.Context, id string) (*vulnerability, error) {
+	baseURL := f.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+
+	client := f.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s/vulns/%s", baseURL, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("querying osv.dev for %q: %w", id, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		// No record for this ID; nothing to alias it to.
+		return &vulnerability{ID: id}, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("querying osv.dev for %q: unexpected status %s", id, resp.Status)
+	}
+
+	var v vulnerability
+	if err := json.NewDecoder(resp.Body).Decode(&v); err != nil {
+		return nil, fmt.Errorf("decoding osv.dev response for %q: %w", id, err)
+	}
+
+	return &v, nil
+}